@@ -21,6 +21,13 @@ const (
 	ReqUnset ReqKey = iota
 	ReqEndpoint
 	ReqAuth
+	// ReqRequestID identifies a single inbound request, generated by the
+	// service if the caller didn't supply one.
+	ReqRequestID
+	// ReqTraceID identifies a distributed trace spanning multiple services.
+	ReqTraceID
+	// ReqSpanID identifies this service's span within a ReqTraceID trace.
+	ReqSpanID
 )
 
 // SvcKey represents a service.
@@ -64,40 +71,92 @@ func NewContextKey(reqKey ReqKey) CtxKey {
 	return CtxKey{svc, reqKey}
 }
 
-// selectedTags determines what tags will be extracted from the context in the
-// tagsFromContext function.
+// selectedTags determines what tags will be extracted from the context in
+// MetricsTagsFromContext (and, by extension, LogTagsFromContext). These
+// values end up as metrics tags, so they must stay low-cardinality.
 var selectedTags = map[string]ReqKey{
 	"endpoint": ReqEndpoint,
 }
 
-// MetricsTagsFromContext extracts pre-defined tags from a context, suitable
-// for passing to the metrics With() tag-defining function.
-func MetricsTagsFromContext(ctx context.Context) []string {
-	tags := make([]string, 0, len(selectedTags)*2)
-	for tagname, tagkey := range selectedTags {
-		tags = append(tags, tagname)
+// logOnlyTags are additional tags LogTagsFromContext extracts that
+// MetricsTagsFromContext does not: per-request identifiers that are
+// exactly what you want on a log line, but would blow up a metrics
+// backend's series count if used as tag values there.
+var logOnlyTags = map[string]ReqKey{
+	"request_id": ReqRequestID,
+	"trace_id":   ReqTraceID,
+	"span_id":    ReqSpanID,
+}
+
+// extractTags resolves each tag in tags against ctx, in the same
+// svc-unset/unset-falling-back shape MetricsTagsFromContext has always used.
+func extractTags(ctx context.Context, tags map[string]ReqKey) []string {
+	out := make([]string, 0, len(tags)*2)
+	for tagname, tagkey := range tags {
+		out = append(out, tagname)
 		if svc == SvcUnset {
-			tags = append(tags, "svc_unset")
+			out = append(out, "svc_unset")
 			continue
 		}
 		val := ctx.Value(NewContextKey(tagkey))
 		// Value was not present in the context.
 		if val == nil {
-			tags = append(tags, "unset")
+			out = append(out, "unset")
 			continue
 		}
-		tags = append(tags, val.(string))
+		out = append(out, val.(string))
 	}
-	return tags
+	return out
+}
+
+// MetricsTagsFromContext extracts pre-defined tags from a context, suitable
+// for passing to the metrics With() tag-defining function.
+func MetricsTagsFromContext(ctx context.Context) []string {
+	return extractTags(ctx, selectedTags)
 }
 
 // LogTagsFromContext extracts pre-defined tags from a a context, suitable
-// for passing to the logging With() context-defining function.
+// for passing to the logging With() context-defining function. It includes
+// everything MetricsTagsFromContext does, plus logOnlyTags.
 func LogTagsFromContext(ctx context.Context) []interface{} {
-	tags := MetricsTagsFromContext(ctx)
+	tags := append(extractTags(ctx, selectedTags), extractTags(ctx, logOnlyTags)...)
 	intfTags := make([]interface{}, len(tags))
 	for i, _ := range tags {
 		intfTags[i] = tags[i]
 	}
 	return intfTags
 }
+
+// WithRequestID attaches a request ID to ctx under the ReqRequestID tag,
+// the same one LogTagsFromContext already extracts via logOnlyTags
+// (MetricsTagsFromContext does not extract it).
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, NewContextKey(ReqRequestID), id)
+}
+
+// RequestIDFromContext returns the request ID attached via WithRequestID,
+// and whether one was present.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(NewContextKey(ReqRequestID)).(string)
+	return id, ok
+}
+
+// ctxLoggerKey is the context key used by WithLogger/LoggerFromContext.
+type ctxLoggerKey struct{}
+
+// WithLogger attaches l, expected to be a logger.Leveler, to ctx so
+// downstream code can recover a request-scoped logger via
+// LoggerFromContext instead of threading one through every call. l is
+// typed as interface{} here rather than logger.Leveler because the logger
+// package imports fctx (for LogTagsFromContext); fctx importing logger back
+// would be a cycle.
+func WithLogger(ctx context.Context, l interface{}) context.Context {
+	return context.WithValue(ctx, ctxLoggerKey{}, l)
+}
+
+// LoggerFromContext returns the logger attached to ctx via WithLogger, and
+// whether one was present.
+func LoggerFromContext(ctx context.Context) (interface{}, bool) {
+	l := ctx.Value(ctxLoggerKey{})
+	return l, l != nil
+}
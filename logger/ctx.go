@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"logkit/fctx"
+)
+
+// Ctx resolves a request-scoped Leveler: the logger most recently attached
+// to ctx via fctx.WithLogger, falling back to the package default if none
+// is present. The returned Leveler is pre-populated with ctx's tags, as
+// WithContext already provides, plus a request id - reused from ctx via
+// fctx.RequestIDFromContext if one is present, generated otherwise.
+//
+// Typical use in HTTP/gRPC middleware:
+//
+//	ctx = fctx.WithRequestID(ctx, incomingRequestID)
+//	ctx = fctx.WithLogger(ctx, logger.WithContext(ctx))
+//	logger.Ctx(ctx).Info().Log("msg", "handling request")
+//
+// Because context.Context is immutable, a request id generated by Ctx only
+// lives for the Leveler it returns; it is not propagated back into ctx.
+// Callers that want one request id shared across several logger.Ctx(ctx)
+// calls should generate it up front with fctx.WithRequestID, as above.
+func Ctx(ctx context.Context) Leveler {
+	l := defaultLevels
+	if stored, ok := fctx.LoggerFromContext(ctx); ok {
+		if lev, ok := stored.(Leveler); ok {
+			l = lev
+		}
+	}
+
+	// Generate the request id into ctx itself, before WithContext extracts
+	// its tags, so the "request_id" keyval it emits is the real value
+	// rather than the "unset" placeholder - WithContext must only ever add
+	// that key once.
+	if _, ok := fctx.RequestIDFromContext(ctx); !ok {
+		ctx = fctx.WithRequestID(ctx, generateRequestID())
+	}
+	return l.WithContext(ctx)
+}
+
+// generateRequestID returns a random 16-byte hex-encoded identifier, used
+// by Ctx when ctx carries none already.
+func generateRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unset"
+	}
+	return hex.EncodeToString(b[:])
+}
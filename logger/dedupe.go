@@ -0,0 +1,35 @@
+package logger
+
+// dedupeKeyvals returns keyvals with only the last occurrence of each
+// string key retained, in that occurrence's original position. This is
+// what lets a later With() call truly override an earlier keyval - e.g.
+// NewSlogHandler replacing Init's "file"/"function" pair with one computed
+// at the right stack depth - regardless of a sink's format: go-kit's own
+// With()/context chain flattens every With() call into a single keyvals
+// slice delivered in one Log call to the root logger, duplicates and all,
+// leaving it to the encoder to collapse repeats itself. Only a map-based
+// encoder (JSON) happens to do that; logfmt writes every pair as-is.
+// dynamicSinkLogger.Log applies this once, right before keyvals reach any
+// sink, so every format behaves the same way. Keyvals whose key isn't a
+// string (malformed input) are always kept, matching go-kit's own
+// leniency about non-string keys.
+func dedupeKeyvals(keyvals []interface{}) []interface{} {
+	lastIndex := make(map[string]int, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if key, ok := keyvals[i].(string); ok {
+			lastIndex[key] = i
+		}
+	}
+
+	out := make([]interface{}, 0, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if key, ok := keyvals[i].(string); ok && lastIndex[key] != i {
+			continue
+		}
+		out = append(out, keyvals[i], keyvals[i+1])
+	}
+	if len(keyvals)%2 != 0 {
+		out = append(out, keyvals[len(keyvals)-1])
+	}
+	return out
+}
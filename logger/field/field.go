@@ -0,0 +1,160 @@
+// Package field provides a typed alternative to the logger package's
+// keyvals ...interface{} API. Every value a Field carries, except one given
+// to Any, is stored in a type-specific slot rather than boxed into an
+// interface{} at the call site, and Value() defers that boxing to the point
+// where an encoder
+// actually needs it.
+package field
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Type discriminates the kind of value a Field carries.
+type Type int8
+
+const (
+	StringType Type = iota
+	Int64Type
+	Float64Type
+	BoolType
+	DurationType
+	TimeType
+	ErrorType
+	StringerType
+	AnyType
+)
+
+// Field is a single structured key/value pair with a typed value.
+type Field struct {
+	Key  string
+	Type Type
+
+	num   int64       // Int64, Bool, Duration, Time (as UnixNano)
+	num2  float64     // Float64
+	str   string      // String
+	iface interface{} // Error, Stringer, Any
+}
+
+// String constructs a string-valued Field.
+func String(key, value string) Field {
+	return Field{Key: key, Type: StringType, str: value}
+}
+
+// Int64 constructs an int64-valued Field.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: Int64Type, num: value}
+}
+
+// Float64 constructs a float64-valued Field.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: Float64Type, num2: value}
+}
+
+// Bool constructs a bool-valued Field.
+func Bool(key string, value bool) Field {
+	var n int64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, Type: BoolType, num: n}
+}
+
+// Duration constructs a time.Duration-valued Field.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, num: int64(value)}
+}
+
+// Time constructs a time.Time-valued Field.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: TimeType, num: value.UnixNano()}
+}
+
+// Error constructs a Field under the conventional "err" key. Unlike the
+// other constructors it fixes its own key, matching logger.LogError's
+// existing "err" convention.
+func Error(err error) Field {
+	return Field{Key: "err", Type: ErrorType, iface: err}
+}
+
+// Stringer constructs a Field whose value is rendered via String() when
+// Value is called, not when the Field is constructed.
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Type: StringerType, iface: value}
+}
+
+// Any constructs a Field from an arbitrary value, for cases the typed
+// constructors above don't cover. It gives up the boxing-avoidance the
+// other constructors provide.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Type: AnyType, iface: value}
+}
+
+// Value returns the field's value boxed as interface{}, for encoders (such
+// as a go-kit log.Logger) that only understand keyval pairs. This is where
+// the boxing the typed constructors avoid on the write side finally
+// happens, once, at the point of encoding.
+func (f Field) Value() interface{} {
+	switch f.Type {
+	case StringType:
+		return f.str
+	case Int64Type:
+		return f.num
+	case Float64Type:
+		return f.num2
+	case BoolType:
+		return f.num != 0
+	case DurationType:
+		return time.Duration(f.num)
+	case TimeType:
+		return time.Unix(0, f.num).UTC()
+	case ErrorType:
+		err, _ := f.iface.(error)
+		return safeError(err)
+	case StringerType:
+		s, _ := f.iface.(fmt.Stringer)
+		return safeStringer(s)
+	default:
+		return f.iface
+	}
+}
+
+// safeError returns err.Error(), or nil if err is a non-nil interface
+// wrapping a nil pointer whose Error method panics on the nil receiver -
+// a typed-nil error is != nil but can't be called safely. This mirrors
+// go-kit/log's own safeError guard, since a panic here would otherwise
+// take down the whole log call rather than just this one field.
+func safeError(err error) (s interface{}) {
+	if err == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+				s = nil
+				return
+			}
+			panic(r)
+		}
+	}()
+	return err.Error()
+}
+
+// safeStringer is safeError's equivalent for fmt.Stringer values.
+func safeStringer(s fmt.Stringer) (out interface{}) {
+	if s == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if v := reflect.ValueOf(s); v.Kind() == reflect.Ptr && v.IsNil() {
+				out = nil
+				return
+			}
+			panic(r)
+		}
+	}()
+	return s.String()
+}
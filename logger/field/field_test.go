@@ -0,0 +1,38 @@
+package field
+
+import "testing"
+
+// typedNilStringer is a *T whose String method dereferences the receiver,
+// the same shape as the real-world Stringer implementations that made
+// go-kit/log add its own safeString guard.
+type typedNilStringer struct{ name string }
+
+func (t *typedNilStringer) String() string { return t.name }
+
+// typedNilError is the error equivalent of typedNilStringer.
+type typedNilError struct{ msg string }
+
+func (e *typedNilError) Error() string { return e.msg }
+
+func TestValueStringerTypedNilDoesNotPanic(t *testing.T) {
+	var s *typedNilStringer
+	f := Stringer("k", s)
+	if got := f.Value(); got != nil {
+		t.Fatalf("Value() = %v, want nil for a typed-nil Stringer", got)
+	}
+}
+
+func TestValueErrorTypedNilDoesNotPanic(t *testing.T) {
+	var e *typedNilError
+	f := Error(e)
+	if got := f.Value(); got != nil {
+		t.Fatalf("Value() = %v, want nil for a typed-nil error", got)
+	}
+}
+
+func TestValueStringerNonNil(t *testing.T) {
+	f := Stringer("k", &typedNilStringer{name: "hi"})
+	if got := f.Value(); got != "hi" {
+		t.Fatalf("Value() = %v, want %q", got, "hi")
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/log"
+
+	"logkit/logger/field"
+)
+
+// Level selects which of the four levels this package exposes a LogFields
+// call should be emitted at.
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// fieldBufPool pools the []interface{} buffers WithFields/LogFields flatten
+// Fields into before handing them to the underlying go-kit logger, so
+// flattening typed Fields reuses a buffer rather than making a new one on
+// every call. go-kit's log.With still copies the pairs into a slice of its
+// own, so each call as a whole still allocates.
+var fieldBufPool = sync.Pool{
+	New: func() interface{} { return make([]interface{}, 0, 8) },
+}
+
+// logFieldsCallerDepth accounts for the two frames between a caller of the
+// package-level LogFields function and where WithCustomDepth's Valuer is
+// actually evaluated: the LogFields wrapper itself, and the levels.LogFields
+// method it calls into. Without this, LogFields' "caller"/"function"
+// keyvals would point at logger.LogFields/levels.LogFields instead of the
+// caller, the same problem WithCustomDepth exists to solve for LogError.
+const logFieldsCallerDepth = nominalStackDepth + 2
+
+// WithFields adds typed Fields to the default Leveler. See Leveler.WithFields.
+func WithFields(fields ...field.Field) Leveler {
+	return defaultLevels.WithFields(fields...)
+}
+
+// LogFields logs msg at lvl with typed Fields against the default Leveler.
+// See Leveler.LogFields.
+func LogFields(lvl Level, msg string, fields ...field.Field) error {
+	return defaultLevels.LogFields(lvl, msg, fields...)
+}
+
+func (l levels) WithFields(fields ...field.Field) Leveler {
+	buf := fieldBufPool.Get().([]interface{})
+	buf = appendFields(buf, fields)
+	next := l.With(buf...)
+	fieldBufPool.Put(buf[:0])
+	return next
+}
+
+func (l levels) LogFields(lvl Level, msg string, fields ...field.Field) error {
+	buf := fieldBufPool.Get().([]interface{})
+	buf = appendFields(buf, fields)
+	scoped := l.WithCustomDepth(logFieldsCallerDepth, buf...)
+	fieldBufPool.Put(buf[:0])
+	return loggerFor(scoped, lvl).Log("msg", msg)
+}
+
+// loggerFor returns l's logger for lvl, the Level equivalent of repeatedly
+// switching on Leveler's own Info/Debug/Warn/Error methods.
+func loggerFor(l Leveler, lvl Level) log.Logger {
+	switch lvl {
+	case LevelDebug:
+		return l.Debug()
+	case LevelWarn:
+		return l.Warn()
+	case LevelError:
+		return l.Error()
+	default:
+		return l.Info()
+	}
+}
+
+// appendFields flattens fields into key, value, key, value, ... pairs
+// appended to buf, boxing each Field's value exactly once.
+func appendFields(buf []interface{}, fields []field.Field) []interface{} {
+	for _, f := range fields {
+		buf = append(buf, f.Key, f.Value())
+	}
+	return buf
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+
+	"logkit/logger/field"
+)
+
+// BenchmarkWithKeyvals exercises the pre-existing keyvals ...interface{}
+// path, as a baseline for BenchmarkLogFields below.
+func BenchmarkWithKeyvals(b *testing.B) {
+	l := levels{log.NewNopLogger()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.With("k", "v").Info().Log("msg", "hello")
+	}
+}
+
+// BenchmarkLogFields exercises the typed Field / pooled-buffer path added
+// alongside it.
+func BenchmarkLogFields(b *testing.B) {
+	l := levels{log.NewNopLogger()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.LogFields(LevelInfo, "hello", field.String("k", "v"))
+	}
+}
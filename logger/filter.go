@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// redactedValue replaces the value of any keyval matched by FilterKey or
+// FilterValue, so secrets/PII reach sinks as a sentinel rather than in the
+// clear.
+const redactedValue = "***"
+
+// levelRank orders the four levels this package exposes so FilterLevel can
+// compare thresholds; level.Value itself carries no ordering.
+var levelRank = map[level.Value]int{
+	level.DebugValue(): 0,
+	level.InfoValue():  1,
+	level.WarnValue():  2,
+	level.ErrorValue(): 3,
+}
+
+// FilterOption configures a Filter constructed by NewFilter.
+type FilterOption func(*filterConfig)
+
+type filterConfig struct {
+	minLevel  level.Value
+	keys      map[string]struct{}
+	values    map[string]struct{}
+	predicate func(level.Value, ...interface{}) bool
+}
+
+// FilterLevel drops any record below lvl, e.g. level.WarnValue() to silence
+// Debug and Info.
+func FilterLevel(lvl level.Value) FilterOption {
+	return func(c *filterConfig) { c.minLevel = lvl }
+}
+
+// FilterKey redacts the value of any keyval whose key is one of keys.
+func FilterKey(keys ...string) FilterOption {
+	return func(c *filterConfig) {
+		for _, k := range keys {
+			c.keys[k] = struct{}{}
+		}
+	}
+}
+
+// FilterValue redacts any keyval whose value is one of values.
+func FilterValue(values ...string) FilterOption {
+	return func(c *filterConfig) {
+		for _, v := range values {
+			c.values[v] = struct{}{}
+		}
+	}
+}
+
+// FilterFunc drops a record whenever fn returns true for its level and
+// keyvals, for filtering logic that doesn't fit the level/key/value cases
+// above.
+func FilterFunc(fn func(level.Value, ...interface{}) bool) FilterOption {
+	return func(c *filterConfig) { c.predicate = fn }
+}
+
+// Filter wraps a log.Logger, dropping records below a level threshold or
+// matched by a custom predicate, and redacting keyvals whose key or value
+// matches a configured list. It composes with the Leveler interface like
+// any other log.Logger: wrap it as levels{internalLogger: filter} (as
+// InitWithFilter does) and .With(...).Info() calls continue to pass through
+// it, since go-kit's log.With still funnels every call through Log.
+type Filter struct {
+	next log.Logger
+	cfg  filterConfig
+}
+
+// NewFilter wraps next so that records passed through the Filter are
+// filtered and redacted according to opts before reaching next.
+func NewFilter(next log.Logger, opts ...FilterOption) *Filter {
+	cfg := filterConfig{
+		keys:   map[string]struct{}{},
+		values: map[string]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Filter{next: next, cfg: cfg}
+}
+
+// Log implements log.Logger.
+func (f *Filter) Log(keyvals ...interface{}) error {
+	lvl := levelOf(keyvals)
+	if f.cfg.minLevel != nil && lvl != nil && levelRank[lvl] < levelRank[f.cfg.minLevel] {
+		return nil
+	}
+	if f.cfg.predicate != nil && f.cfg.predicate(lvl, keyvals...) {
+		return nil
+	}
+
+	if len(f.cfg.keys) > 0 || len(f.cfg.values) > 0 {
+		keyvals = f.redact(keyvals)
+	}
+	return f.next.Log(keyvals...)
+}
+
+// redact returns a copy of keyvals with any matched key or value replaced by
+// redactedValue, leaving the original slice (which may be shared with a
+// caller's With chain) untouched.
+func (f *Filter) redact(keyvals []interface{}) []interface{} {
+	out := append([]interface{}(nil), keyvals...)
+	for i := 0; i+1 < len(out); i += 2 {
+		if key, ok := out[i].(string); ok {
+			if _, matched := f.cfg.keys[key]; matched {
+				out[i+1] = redactedValue
+				continue
+			}
+		}
+		if value, ok := out[i+1].(string); ok {
+			if _, matched := f.cfg.values[value]; matched {
+				out[i+1] = redactedValue
+			}
+		}
+	}
+	return out
+}
+
+// levelOf returns the level.Value go-kit's level.Info/Debug/Warn/Error
+// attaches to a record's keyvals, or nil if none is present.
+func levelOf(keyvals []interface{}) level.Value {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == level.Key() {
+			if v, ok := keyvals[i+1].(level.Value); ok {
+				return v
+			}
+		}
+	}
+	return nil
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"os"
-
 	stdlog "log"
 
 	"github.com/go-kit/kit/log"
@@ -14,6 +12,7 @@ import (
 	"github.com/go-stack/stack"
 
 	"logkit/fctx"
+	"logkit/logger/field"
 )
 
 type LogFormat int32
@@ -37,24 +36,70 @@ func init() {
 	Init(defaultFormat)
 }
 
-// Init sets the default logger to the desired format and initializes a few
-// logging values.  Note that all keyvals added by calling AddDefaultKeyvals
-// before Init will be removed.
+// Init sets the default logger to write format-encoded records to stdout
+// and initializes a few logging values. Note that all keyvals added by
+// calling AddDefaultKeyvals before Init will be removed. This is a thin
+// convenience wrapper around InitWithConfig for the common single-sink case.
 func Init(format LogFormat) {
-	var l log.Logger
-	switch format {
-	case FormatJson:
-		l = log.NewJSONLogger(os.Stdout)
-	case FormatLogfmt:
-		l = log.NewLogfmtLogger(os.Stdout)
-	case FormatNop:
-		l = log.NewNopLogger()
-	default:
-		panic(fmt.Errorf("invalid log format: %v", format))
+	InitWithConfig(Config{Sinks: []SinkConfig{{Output: OutputStdout, Format: format}}})
+}
+
+// InitWithConfig sets the default logger to fan out every Log call to the
+// sinks described by cfg, and initializes a few logging values. Note that
+// all keyvals added by calling AddDefaultKeyvals before InitWithConfig will
+// be removed.
+//
+// InitWithConfig may be called again later to reconfigure the active sinks
+// (e.g. in response to a config reload); the swap happens behind an atomic
+// pointer, so in-flight Log calls see either the old sink set or the new
+// one in full, never a mix of the two.
+func InitWithConfig(cfg Config) {
+	initSinks(cfg)
+	defaultLevels = levels{dynamicSinkLogger{}}
+	finishInit()
+}
+
+// InitWithFilter is InitWithConfig plus a Filter sitting in front of the
+// sinks: every Log call, including those made through .With(...) chains
+// built on top of the Leveler this installs, is filtered and redacted
+// according to opts before it reaches cfg's sinks.
+func InitWithFilter(cfg Config, opts ...FilterOption) {
+	initSinks(cfg)
+	defaultLevels = levels{NewFilter(dynamicSinkLogger{}, opts...)}
+	finishInit()
+}
+
+// InitWithSampler is InitWithConfig plus a Sampler sitting in front of the
+// sinks, bounding how much of any one message reaches cfg's sinks per
+// samplerCfg. Like InitWithFilter, the wrapper is installed as the default
+// Leveler's internal logger, so .With(...) chains built on it are sampled
+// too.
+func InitWithSampler(cfg Config, samplerCfg SamplerConfig) {
+	initSinks(cfg)
+	defaultLevels = levels{NewSampledLogger(dynamicSinkLogger{}, samplerCfg)}
+	finishInit()
+}
+
+// initSinks builds and installs the sinks described by cfg.
+func initSinks(cfg Config) {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sinkCfg := range cfg.Sinks {
+		sink, err := buildSink(sinkCfg)
+		if err != nil {
+			panic(err)
+		}
+		sinks = append(sinks, sink)
 	}
-	defaultFormat = format
-	defaultLevels = levels{l}
+	setSinks(sinks)
 
+	if len(cfg.Sinks) > 0 {
+		defaultFormat = cfg.Sinks[0].Format
+	}
+}
+
+// finishInit (re-)establishes the default keyvals every Init variant adds:
+// timestamp, caller file, and calling function.
+func finishInit() {
 	AddDefaultKeyvals(
 		"timestamp", log.DefaultTimestampUTC,
 		"file", caller(nominalStackDepth),
@@ -96,7 +141,6 @@ func Error() log.Logger {
 	return defaultLevels.Error()
 }
 
-
 // With adds the key value pairs to the Leveler.
 func With(keyvals ...interface{}) Leveler {
 	return defaultLevels.With(keyvals...)
@@ -153,6 +197,14 @@ type Leveler interface {
 	// context and a consistent "err" key for the error value. Other key-values
 	// can be provided after the error value.
 	LogError(err error, keyvals ...interface{}) error
+
+	// WithFields adds typed Fields to the Leveler. Each Field's value is
+	// boxed once via Field.Value and the resulting pairs are passed to With.
+	WithFields(fields ...field.Field) Leveler
+	// LogFields logs msg at lvl with typed Fields. The Fields are flattened
+	// into keyvals in a pooled buffer, which then goes through the same
+	// With/Log path as the keyvals API; no encoder is written to directly.
+	LogFields(lvl Level, msg string, fields ...field.Field) error
 }
 
 // levels implements Leveler and stores an internal logger
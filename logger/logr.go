@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// logrSink adapts a Leveler into a logr.LogSink, so a logkit-based service
+// can be handed to Kubernetes-adjacent code that only speaks logr.
+type logrSink struct {
+	l    Leveler
+	name string
+}
+
+// NewLogrSink wraps l as a logr.LogSink. Use it with logr.New to get a
+// logr.Logger: logr.New(logger.NewLogrSink(logger.With(...))).
+func NewLogrSink(l Leveler) logr.LogSink {
+	return &logrSink{l: l}
+}
+
+// Init implements logr.LogSink. logr's RuntimeInfo doesn't expose enough
+// about its call chain to adjust our caller/function depth reliably (unlike
+// the slog bridge, where the frames slog.Logger adds are fixed - see
+// slogCallerDepth), so this is a no-op.
+func (s *logrSink) Init(info logr.RuntimeInfo) {}
+
+// Enabled always reports true: level filtering is the wrapped Leveler's
+// concern (e.g. a Filter installed via InitWithFilter), not the sink's.
+func (s *logrSink) Enabled(level int) bool {
+	return true
+}
+
+// Info implements logr.LogSink. logr's verbosity level follows the "V(n)"
+// convention: 0 is the default/most important, and higher numbers are
+// progressively more verbose - the inverse of this package's level
+// ordering - so anything above 0 maps to Debug and 0 maps to Info.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	logger := s.l.Info()
+	if level > 0 {
+		logger = s.l.Debug()
+	}
+	logger.Log(s.keyvals(msg, keysAndValues)...)
+}
+
+// Error implements logr.LogSink.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	keyvals := append(s.keyvals(msg, keysAndValues), "err", err)
+	s.l.Error().Log(keyvals...)
+}
+
+func (s *logrSink) keyvals(msg string, keysAndValues []interface{}) []interface{} {
+	keyvals := make([]interface{}, 0, 4+len(keysAndValues))
+	if s.name != "" {
+		keyvals = append(keyvals, "logger", s.name)
+	}
+	keyvals = append(keyvals, "msg", msg)
+	return append(keyvals, keysAndValues...)
+}
+
+// WithValues implements logr.LogSink.
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &logrSink{l: s.l.With(keysAndValues...), name: s.name}
+}
+
+// WithName implements logr.LogSink, nesting names with "." like logr's own
+// sinks conventionally do.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	full := name
+	if s.name != "" {
+		full = s.name + "." + name
+	}
+	return &logrSink{l: s.l, name: full}
+}
@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// SamplerStrategy selects how a Sampler decides which records to drop.
+type SamplerStrategy int32
+
+const (
+	// SamplerTokenBucket allows up to RatePerSecond records per second for
+	// a given sample key, dropping the rest.
+	SamplerTokenBucket SamplerStrategy = iota
+	// SamplerTailBased allows the first First records of a window through,
+	// then only every Every'th record after that.
+	SamplerTailBased
+)
+
+// samplerShardCount is the number of stripes a Sampler spreads its sample
+// states across, each guarded by its own RWMutex, to keep the hot path from
+// serializing on a single lock.
+const samplerShardCount = 32
+
+// defaultSampleKeyFields is used when SamplerConfig.KeyFields is empty: the
+// "msg" keyval is the conventional stable identifier for a given log
+// statement across calls.
+var defaultSampleKeyFields = []string{"msg"}
+
+// SamplerConfig configures a Sampler. The zero value is a token bucket that
+// drops everything (RatePerSecond 0); callers are expected to set at least
+// RatePerSecond or First/Every depending on Strategy.
+type SamplerConfig struct {
+	Strategy SamplerStrategy
+
+	// RatePerSecond is the token bucket's allowance per (level, sample key)
+	// tuple, per second. Only used by SamplerTokenBucket.
+	RatePerSecond int
+
+	// First is how many records of a window to let through unconditionally.
+	// Every is the "let one through every Mth record" rate after First has
+	// been exhausted. Window resets First/Every's counters; zero means
+	// never reset. Only used by SamplerTailBased.
+	First  int
+	Every  int
+	Window time.Duration
+
+	// KeyFields selects which keyvals (by key name) make up the sample key,
+	// so that unrelated messages don't share - and starve - one another's
+	// budget. Defaults to []string{"msg"}.
+	KeyFields []string
+
+	// SampleErrors, if false (the default), makes error-level records
+	// bypass sampling entirely so failures are never silently dropped.
+	SampleErrors bool
+}
+
+// sampleState is the per-sample-key counters a Sampler consults to decide
+// whether to allow or drop the next record carrying that key.
+type sampleState struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	tokens      int // token bucket: records allowed so far this second
+	seen        int // tail-based: records seen so far this window
+	dropped     int // records dropped since the last one let through
+}
+
+func (s *sampleState) allowTokenBucket(rate int, now time.Time) (allowed bool, dropped int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		s.tokens = 0
+	}
+	if s.tokens >= rate {
+		s.dropped++
+		return false, 0
+	}
+	s.tokens++
+	dropped, s.dropped = s.dropped, 0
+	return true, dropped
+}
+
+func (s *sampleState) allowTailBased(first, every int, window time.Duration, now time.Time) (allowed bool, dropped int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if window > 0 && now.Sub(s.windowStart) >= window {
+		s.windowStart = now
+		s.seen = 0
+	}
+	s.seen++
+	if s.seen > first {
+		if every <= 0 {
+			every = 1
+		}
+		if (s.seen-first)%every != 0 {
+			s.dropped++
+			return false, 0
+		}
+	}
+	dropped, s.dropped = s.dropped, 0
+	return true, dropped
+}
+
+// samplerShard is one stripe of a Sampler's sample-key space.
+type samplerShard struct {
+	mu     sync.RWMutex
+	states map[string]*sampleState
+}
+
+// Sampler wraps a log.Logger, bounding how many records with a given sample
+// key reach next per second (SamplerTokenBucket) or per window
+// (SamplerTailBased). When a record is let through after prior ones were
+// dropped, Sampler adds a "sampled" keyval reporting how many were dropped.
+type Sampler struct {
+	next   log.Logger
+	cfg    SamplerConfig
+	shards [samplerShardCount]samplerShard
+}
+
+// NewSampledLogger wraps next with a Sampler configured by cfg.
+func NewSampledLogger(next log.Logger, cfg SamplerConfig) *Sampler {
+	s := &Sampler{next: next, cfg: cfg}
+	for i := range s.shards {
+		s.shards[i].states = map[string]*sampleState{}
+	}
+	return s
+}
+
+// Log implements log.Logger.
+func (s *Sampler) Log(keyvals ...interface{}) error {
+	lvl := levelOf(keyvals)
+	if lvl == level.ErrorValue() && !s.cfg.SampleErrors {
+		return s.next.Log(keyvals...)
+	}
+
+	state := s.stateFor(s.sampleKey(lvl, keyvals))
+
+	now := time.Now()
+	var allowed bool
+	var dropped int
+	switch s.cfg.Strategy {
+	case SamplerTailBased:
+		allowed, dropped = state.allowTailBased(s.cfg.First, s.cfg.Every, s.cfg.Window, now)
+	default:
+		allowed, dropped = state.allowTokenBucket(s.cfg.RatePerSecond, now)
+	}
+	if !allowed {
+		return nil
+	}
+	if dropped > 0 {
+		keyvals = append(append([]interface{}{}, keyvals...), "sampled", dropped)
+	}
+	return s.next.Log(keyvals...)
+}
+
+// sampleKey builds the stable identifier a record is bucketed under, from
+// its level plus the configured KeyFields' values.
+func (s *Sampler) sampleKey(lvl level.Value, keyvals []interface{}) string {
+	fields := s.cfg.KeyFields
+	if len(fields) == 0 {
+		fields = defaultSampleKeyFields
+	}
+
+	var b strings.Builder
+	if lvl != nil {
+		b.WriteString(lvl.String())
+	}
+	for _, field := range fields {
+		b.WriteByte('|')
+		b.WriteString(field)
+		b.WriteByte('=')
+		fmt.Fprint(&b, valueOf(keyvals, field))
+	}
+	return b.String()
+}
+
+// valueOf returns the value paired with key in keyvals, or nil if absent.
+func valueOf(keyvals []interface{}, key string) interface{} {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+func (s *Sampler) stateFor(key string) *sampleState {
+	shard := &s.shards[shardIndex(key)]
+
+	shard.mu.RLock()
+	st, ok := shard.states[key]
+	shard.mu.RUnlock()
+	if ok {
+		return st
+	}
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if st, ok := shard.states[key]; ok {
+		return st
+	}
+	st = &sampleState{windowStart: time.Now()}
+	shard.states[key] = st
+	return st
+}
+
+func shardIndex(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % samplerShardCount
+}
@@ -0,0 +1,262 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+// OutputKind selects the destination a Sink writes to.
+type OutputKind int32
+
+const (
+	// OutputStdout writes to the process's standard output. This is the
+	// default and preserves the pre-existing Init(format) behaviour.
+	OutputStdout OutputKind = iota
+	// OutputFile writes to a rotating file on disk. See SinkConfig's
+	// MaxSizeMB/MaxAgeDays fields.
+	OutputFile
+	// OutputFS writes to a fixed path on a mounted filesystem without
+	// rotation, intended for containerized deployments where an external
+	// agent tails/rotates the file.
+	OutputFS
+	// OutputSyslog writes to the local syslog daemon.
+	OutputSyslog
+	// OutputNetwork writes to a remote TCP or UDP endpoint.
+	OutputNetwork
+)
+
+// SinkConfig describes a single log destination: what it writes to and which
+// LogFormat it should encode records with. Not all fields apply to every
+// Output kind; see the Output* constants for which fields they read.
+type SinkConfig struct {
+	Output OutputKind
+	Format LogFormat
+
+	// Path is the destination file path for OutputFile and OutputFS.
+	Path string
+	// MaxSizeMB rotates OutputFile once the active file reaches this size.
+	// Zero disables size-based rotation.
+	MaxSizeMB int
+	// MaxAgeDays rotates OutputFile once the sink has had the active file
+	// open this long; the age counts from when the sink opened the file,
+	// not from the file's creation. Zero disables age-based rotation.
+	MaxAgeDays int
+
+	// Network is "tcp" or "udp" for OutputNetwork.
+	Network string
+	// Address is the dial address for OutputNetwork, e.g. "logs.internal:514".
+	Address string
+
+	// SyslogTag is the program tag reported to the syslog daemon. Empty
+	// defaults to the standard library's own default tag.
+	SyslogTag string
+}
+
+// Config configures the full set of sinks a Leveler fans its Log calls out
+// to. See InitWithConfig.
+type Config struct {
+	Sinks []SinkConfig
+}
+
+// Sink is a single configured log destination: a writer plus the LogFormat
+// used to encode records before they reach it.
+type Sink struct {
+	Format LogFormat
+	Logger log.Logger
+
+	// closer is optional and released by Close, e.g. file handles and
+	// network connections. Stdout is never closed.
+	closer io.Closer
+}
+
+// Close releases any resource (file handle, network connection) backing the
+// sink. Sinks without one, such as stdout, are a no-op.
+func (s Sink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// newSink builds a Sink that writes format-encoded records to w, keeping closer (if
+// non-nil) so the sink can be torn down on reconfiguration.
+func newSink(format LogFormat, w io.Writer, closer io.Closer) Sink {
+	return Sink{
+		Format: format,
+		Logger: newFormattedLogger(format, w),
+		closer: closer,
+	}
+}
+
+// newFormattedLogger wraps w with the go-kit logger matching format.
+func newFormattedLogger(format LogFormat, w io.Writer) log.Logger {
+	switch format {
+	case FormatJson:
+		return log.NewJSONLogger(w)
+	case FormatLogfmt:
+		return log.NewLogfmtLogger(w)
+	case FormatNop:
+		return log.NewNopLogger()
+	default:
+		panic(fmt.Errorf("invalid log format: %v", format))
+	}
+}
+
+// buildSink constructs the Sink described by cfg.
+func buildSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Output {
+	case OutputStdout:
+		return newSink(cfg.Format, os.Stdout, nil), nil
+	case OutputFile:
+		w, err := newRotatingFile(cfg.Path, cfg.MaxSizeMB, cfg.MaxAgeDays)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logger: building file sink: %w", err)
+		}
+		return newSink(cfg.Format, w, w), nil
+	case OutputFS:
+		w, err := newFSWriter(cfg.Path)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logger: building fs sink: %w", err)
+		}
+		return newSink(cfg.Format, w, w), nil
+	case OutputSyslog:
+		w, err := newSyslogWriter(cfg.SyslogTag)
+		if err != nil {
+			return Sink{}, fmt.Errorf("logger: building syslog sink: %w", err)
+		}
+		return newSink(cfg.Format, w, w), nil
+	case OutputNetwork:
+		w := newNetWriter(cfg.Network, cfg.Address)
+		return newSink(cfg.Format, w, w), nil
+	default:
+		return Sink{}, fmt.Errorf("logger: invalid sink output kind: %v", cfg.Output)
+	}
+}
+
+// sinkSet is the group of sinks a dynamicSinkLogger fans out to for one
+// sinkGeneration. A generation is held behind an atomic.Pointer so
+// InitWithConfig can swap it out without racing with in-flight Log calls:
+// a call either sees the old set in full or the new one, never a partial
+// mix - see sinkGeneration for how the old set's sinks are then closed
+// safely.
+type sinkSet []Sink
+
+// Log satisfies log.Logger by writing keyvals to every sink in the set,
+// returning the first error encountered (if any) after attempting all of
+// them so one failing sink doesn't suppress the others.
+func (s sinkSet) Log(keyvals ...interface{}) error {
+	var firstErr error
+	for _, sink := range s {
+		if err := sink.Logger.Log(keyvals...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// sinkGenClosing is set in sinkGeneration.refs once a generation has been
+// swapped out and is draining, so no further Log call can newly acquire it.
+const sinkGenClosing = int64(1) << 62
+
+// sinkDrainTimeout bounds how long setSinks waits for in-flight Log calls
+// against a retired sinkGeneration to finish before closing its sinks
+// anyway, so a stuck writer (e.g. a network sink blocked on a wedged
+// connection) can't stall reconfiguration forever.
+const sinkDrainTimeout = 5 * time.Second
+
+// sinkGeneration pairs a sinkSet with the bookkeeping setSinks needs to
+// close it safely: refs counts Log calls currently in flight against
+// sinks, with the sinkGenClosing bit (bit 62) marking the generation as
+// retired. A Log call acquires before using sinks and releases after, so a
+// retired generation is only closed once every call that acquired it while
+// still active has released, or sinkDrainTimeout has passed - see
+// acquire/release and closeWhenIdle.
+type sinkGeneration struct {
+	sinks sinkSet
+	refs  int64
+}
+
+// acquire registers a Log call against g, returning false if g has already
+// been retired (in which case the caller has nothing live to write to and
+// should treat it the same as no sinks being configured).
+func (g *sinkGeneration) acquire() bool {
+	for {
+		old := atomic.LoadInt64(&g.refs)
+		if old&sinkGenClosing != 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&g.refs, old, old+1) {
+			return true
+		}
+	}
+}
+
+// release deregisters a Log call previously registered via acquire.
+func (g *sinkGeneration) release() {
+	atomic.AddInt64(&g.refs, -1)
+}
+
+// closeWhenIdle marks g as retired, refusing any further acquire calls, then
+// waits for Log calls that already acquired it to release before closing
+// its sinks - so a write-in-progress never sees its sink closed out from
+// under it, and a reopen()'d (and now untracked) file handle never leaks.
+// The wait is bounded by sinkDrainTimeout so a wedged sink can't stall
+// reconfiguration indefinitely.
+func (g *sinkGeneration) closeWhenIdle() {
+	for {
+		old := atomic.LoadInt64(&g.refs)
+		if old&sinkGenClosing != 0 {
+			break
+		}
+		if atomic.CompareAndSwapInt64(&g.refs, old, old|sinkGenClosing) {
+			break
+		}
+	}
+
+	deadline := time.Now().Add(sinkDrainTimeout)
+	for atomic.LoadInt64(&g.refs)&^sinkGenClosing != 0 && time.Now().Before(deadline) {
+		runtime.Gosched()
+	}
+
+	for _, sink := range g.sinks {
+		sink.Close()
+	}
+}
+
+var activeGeneration atomic.Pointer[sinkGeneration]
+
+// dynamicSinkLogger is a stable log.Logger value that always fans out to
+// whatever sinkSet is currently active. Because it's the same value across
+// reconfigurations, Levelers built with log.With(...) around it continue to
+// pick up new sinks after InitWithConfig swaps activeGeneration.
+type dynamicSinkLogger struct{}
+
+func (dynamicSinkLogger) Log(keyvals ...interface{}) error {
+	gen := activeGeneration.Load()
+	if gen == nil {
+		return nil
+	}
+	if !gen.acquire() {
+		// Lost the race with a reconfigure already tearing this
+		// generation down; there's nothing live left to log to.
+		return nil
+	}
+	defer gen.release()
+	return gen.sinks.Log(dedupeKeyvals(keyvals)...)
+}
+
+// setSinks atomically installs sinks as the active generation, draining and
+// closing whatever generation was previously active.
+func setSinks(sinks []Sink) {
+	old := activeGeneration.Swap(&sinkGeneration{sinks: sinks})
+	if old == nil {
+		return
+	}
+	old.closeWhenIdle()
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// rotatingFile is an io.WriteCloser that rotates the underlying file before
+// a write that would take it past maxSizeMB, and/or once it has been open
+// for maxAgeDays. A zero threshold disables that check.
+// The rotated-out file is renamed with a timestamp suffix; callers that want
+// pruning of old rotated files should do so out of band (e.g. logrotate, a
+// sidecar, or the orchestrator's volume policy).
+type rotatingFile struct {
+	path       string
+	maxSizeMB  int
+	maxAgeDays int
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func newRotatingFile(path string, maxSizeMB, maxAgeDays int) (*rotatingFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file sink requires a non-empty path")
+	}
+	rf := &rotatingFile{
+		path:       path,
+		maxSizeMB:  maxSizeMB,
+		maxAgeDays: maxAgeDays,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(rf.path), 0o755); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stating log file: %w", err)
+	}
+	rf.file = f
+	rf.size = info.Size()
+	rf.openedAt = time.Now()
+	return nil
+}
+
+// Write implements io.Writer, rotating the file first if p would take it
+// past maxSizeMB or it has been open for maxAgeDays or longer.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.needsRotation(len(p)) {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) needsRotation(nextWrite int) bool {
+	if rf.maxSizeMB > 0 && rf.size+int64(nextWrite) > int64(rf.maxSizeMB)*1024*1024 {
+		return true
+	}
+	if rf.maxAgeDays > 0 && time.Since(rf.openedAt) >= time.Duration(rf.maxAgeDays)*24*time.Hour {
+		return true
+	}
+	return false
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("closing rotated log file: %w", err)
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", rf.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	renameErr := os.Rename(rf.path, rotatedPath)
+
+	// Reopen rf.path regardless of whether the rename succeeded: on
+	// success this starts the fresh post-rotation file, and on failure
+	// the original file is still there under the old name, so this
+	// recovers it rather than leaving the sink with a closed handle and
+	// no way to write again.
+	if err := rf.open(); err != nil {
+		if renameErr != nil {
+			return fmt.Errorf("renaming rotated log file: %w (and reopening failed too: %v)", renameErr, err)
+		}
+		return fmt.Errorf("reopening log file after rotation: %w", err)
+	}
+	if renameErr != nil {
+		return fmt.Errorf("renaming rotated log file: %w", renameErr)
+	}
+	return nil
+}
+
+// Close implements io.Closer.
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRotatingFileRecoversFromFailedRename guards against rotate() leaving
+// rf without a usable file handle when the rename step fails: it should
+// still reopen rf.path so logging can continue, rather than reporting an
+// error and then silently dropping every write after it.
+func TestRotatingFileRecoversFromFailedRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	rf, err := newRotatingFile(path, 0, 0)
+	if err != nil {
+		t.Fatalf("newRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	// Force rotate()'s os.Rename to fail by removing its source out from
+	// under it.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	err = rf.rotate()
+	if err == nil {
+		t.Fatal("expected rotate to report the failed rename")
+	}
+	if !strings.Contains(err.Error(), "renaming rotated log file") {
+		t.Fatalf("rotate() error = %v, want one mentioning the failed rename", err)
+	}
+
+	n, err := rf.Write([]byte("hello\n"))
+	if err != nil {
+		t.Fatalf("Write after failed rename: %v", err)
+	}
+	if n != len("hello\n") {
+		t.Fatalf("Write returned n=%d, want %d", n, len("hello\n"))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "hello\n" {
+		t.Fatalf("file contents = %q, want %q", data, "hello\n")
+	}
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fsWriter is a plain append-only file writer with no rotation, intended for
+// containerized deployments where the orchestrator (or a sidecar) owns log
+// rotation and retention for a mounted volume. It reopens the file lazily if
+// a write fails, in case the mount was replaced out from under it.
+type fsWriter struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFSWriter(path string) (*fsWriter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("fs sink requires a non-empty path")
+	}
+	w := &fsWriter{path: path}
+	if err := w.reopen(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *fsWriter) reopen() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+		return fmt.Errorf("creating fs sink directory: %w", err)
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening fs sink file: %w", err)
+	}
+	w.file = f
+	return nil
+}
+
+// Write implements io.Writer, retrying once against a freshly (re)opened file
+// if the first attempt fails.
+func (w *fsWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n, err := w.file.Write(p)
+	if err == nil {
+		return n, nil
+	}
+	if reopenErr := w.reopen(); reopenErr != nil {
+		return n, err
+	}
+	return w.file.Write(p)
+}
+
+// Close implements io.Closer.
+func (w *fsWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// dialTimeout bounds how long a netWriter waits to (re)establish its
+// connection before giving up on a given Write call.
+const dialTimeout = 5 * time.Second
+
+// netWriter is an io.WriteCloser that streams log records to a remote TCP or
+// UDP endpoint. The connection is dialed lazily on first use and redialed on
+// the next write whenever a prior write failed, so a temporarily unreachable
+// collector doesn't prevent process startup.
+type netWriter struct {
+	network string
+	address string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newNetWriter(network, address string) *netWriter {
+	return &netWriter{network: network, address: address}
+}
+
+// Write implements io.Writer, (re)dialing the destination if there is no
+// live connection.
+func (w *netWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.address, dialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		w.conn = conn
+	}
+
+	n, err := w.conn.Write(p)
+	if err != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (w *netWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
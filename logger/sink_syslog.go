@@ -0,0 +1,15 @@
+//go:build !windows
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter dials the local syslog daemon, tagging records with tag (or
+// the standard library's default tag if empty). syslog.Writer is itself safe
+// for concurrent use, so it can be used directly as the sink's io.Writer.
+func newSyslogWriter(tag string) (io.WriteCloser, error) {
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_USER, tag)
+}
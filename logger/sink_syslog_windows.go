@@ -0,0 +1,15 @@
+//go:build windows
+
+package logger
+
+import (
+	"fmt"
+	"io"
+)
+
+// newSyslogWriter is unavailable on Windows: the standard library's
+// log/syslog package only supports Unix-domain and network syslog on unix
+// platforms.
+func newSyslogWriter(tag string) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("logger: syslog sink is not supported on windows")
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+type logFunc func(keyvals ...interface{}) error
+
+func (f logFunc) Log(keyvals ...interface{}) error { return f(keyvals...) }
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+// TestSetSinksDrainsInFlightWriters guards against the race where setSinks
+// closes a retired sink while a Log call that already loaded it is still
+// mid-write: for fsWriter that write-after-close triggers reopen(), which
+// silently recreates and leaks an untracked file handle. A Close call that
+// races ahead of the in-flight writer here would be observed before
+// release is closed below, rather than after.
+func TestSetSinksDrainsInFlightWriters(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	closed := make(chan struct{})
+
+	sink := Sink{
+		Logger: logFunc(func(keyvals ...interface{}) error {
+			close(started)
+			<-release
+			return nil
+		}),
+		closer: closerFunc(func() error {
+			close(closed)
+			return nil
+		}),
+	}
+	setSinks([]Sink{sink})
+	t.Cleanup(func() { setSinks(nil) })
+
+	logDone := make(chan struct{})
+	go func() {
+		dynamicSinkLogger{}.Log("msg", "hi")
+		close(logDone)
+	}()
+	<-started // the in-flight Log call has acquired the generation
+
+	reconfigureDone := make(chan struct{})
+	go func() {
+		setSinks(nil)
+		close(reconfigureDone)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("sink was closed before the in-flight Log call finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-logDone
+	<-reconfigureDone
+
+	select {
+	case <-closed:
+	default:
+		t.Fatal("sink was never closed after the in-flight Log call finished")
+	}
+}
@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"context"
+	stdlog "log"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+)
+
+// slogCallerDepth accounts for the extra frames slog.Logger's Info/Debug/
+// Warn/Error methods and its own internal dispatch add between a caller's
+// call site and slogHandler.Handle being invoked, so the "caller"/
+// "function" keyvals still point at the slog caller rather than somewhere
+// inside log/slog.
+const slogCallerDepth = nominalStackDepth + 3
+
+// slogHandler adapts a Leveler into a slog.Handler, so a logkit-based
+// service can be handed to code that only speaks slog (slog.SetDefault,
+// a library accepting *slog.Logger, etc).
+type slogHandler struct {
+	l      Leveler
+	groups []string
+}
+
+// NewSlogHandler wraps l as a slog.Handler. It overrides l's "file"/
+// "function" keyvals - baked in by Init at the depth a direct
+// logger.Info()/Debug() call expects, which is wrong once slog's own
+// frames sit between the caller and Handle - with versions computed at
+// slogCallerDepth. It reuses Init's own key names rather than
+// WithCustomDepth's "caller" so a record carries one authoritative
+// file/function pair instead of two: dynamicSinkLogger.Log dedupes the
+// flattened keyvals chain right before it reaches a sink, keeping the last
+// occurrence of each key, so this corrected pair wins regardless of sink
+// format.
+func NewSlogHandler(l Leveler) slog.Handler {
+	return &slogHandler{l: l.With(
+		"file", caller(slogCallerDepth),
+		"function", function(slogCallerDepth),
+	)}
+}
+
+// Enabled always reports true: level filtering is the wrapped Leveler's
+// concern (e.g. a Filter installed via InitWithFilter), not the handler's.
+func (h *slogHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	keyvals := make([]interface{}, 0, 2+2*r.NumAttrs())
+	keyvals = append(keyvals, "msg", r.Message)
+	r.Attrs(func(a slog.Attr) bool {
+		keyvals = appendSlogAttr(keyvals, h.groups, a)
+		return true
+	})
+	return h.loggerFor(r.Level).Log(keyvals...)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	keyvals := make([]interface{}, 0, 2*len(attrs))
+	for _, a := range attrs {
+		keyvals = appendSlogAttr(keyvals, h.groups, a)
+	}
+	return &slogHandler{l: h.l.With(keyvals...), groups: h.groups}
+}
+
+// WithGroup implements slog.Handler. Attrs logged under the returned
+// handler have their keys prefixed with name, matching slog's own nested
+// group semantics.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	groups := append(append([]string{}, h.groups...), name)
+	return &slogHandler{l: h.l, groups: groups}
+}
+
+func (h *slogHandler) loggerFor(lvl slog.Level) log.Logger {
+	switch {
+	case lvl >= slog.LevelError:
+		return h.l.Error()
+	case lvl >= slog.LevelWarn:
+		return h.l.Warn()
+	case lvl >= slog.LevelInfo:
+		return h.l.Info()
+	default:
+		return h.l.Debug()
+	}
+}
+
+// appendSlogAttr flattens a into keyvals, recursing into group attrs and
+// prefixing their keys with the enclosing groups joined by ".".
+func appendSlogAttr(keyvals []interface{}, groups []string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		nested := append(append([]string{}, groups...), a.Key)
+		for _, child := range a.Value.Group() {
+			keyvals = appendSlogAttr(keyvals, nested, child)
+		}
+		return keyvals
+	}
+
+	key := a.Key
+	if len(groups) > 0 {
+		key = strings.Join(groups, ".") + "." + a.Key
+	}
+	return append(keyvals, key, a.Value.Any())
+}
+
+// FromSlog adapts an existing slog.Handler into a Leveler, the reverse of
+// NewSlogHandler, so a service whose own handler is slog-based can still be
+// consumed through this package's Info/Debug/Warn/Error/With API.
+func FromSlog(h slog.Handler) Leveler {
+	return levels{slogBackedLogger{handler: h}}
+}
+
+// slogBackedLogger implements log.Logger by translating a Log call into an
+// slog.Record and handing it to the wrapped slog.Handler.
+type slogBackedLogger struct {
+	handler slog.Handler
+}
+
+func (s slogBackedLogger) Log(keyvals ...interface{}) error {
+	lvl := levelOf(keyvals)
+	msg, rest := extractMsg(keyvals)
+	r := slog.NewRecord(time.Now(), slogLevelFor(lvl), msg, 0)
+	r.Add(rest...)
+	return s.handler.Handle(context.Background(), r)
+}
+
+func slogLevelFor(lvl level.Value) slog.Level {
+	switch lvl {
+	case level.DebugValue():
+		return slog.LevelDebug
+	case level.WarnValue():
+		return slog.LevelWarn
+	case level.ErrorValue():
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// extractMsg pulls the first "msg" keyval out of keyvals (go-kit's own
+// convention for the human-readable message), returning it along with the
+// remaining keyvals in order.
+func extractMsg(keyvals []interface{}) (string, []interface{}) {
+	rest := make([]interface{}, 0, len(keyvals))
+	var msg string
+	var foundMsg bool
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, _ := keyvals[i].(string)
+		if !foundMsg && key == "msg" {
+			if s, ok := keyvals[i+1].(string); ok {
+				msg = s
+				foundMsg = true
+				continue
+			}
+		}
+		rest = append(rest, keyvals[i], keyvals[i+1])
+	}
+	return msg, rest
+}
+
+// UseSlogHandler installs h as both the default slog handler and the
+// destination for the standard library's log package, so code written
+// against logkit, slog, and the stdlib logger all funnel through the same
+// output pipeline.
+func UseSlogHandler(h slog.Handler) {
+	slog.SetDefault(slog.New(h))
+	stdlog.SetOutput(slog.NewLogLogger(h, slog.LevelInfo).Writer())
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewSlogHandlerOverridesCallerKeyvals exercises NewSlogHandler against
+// both sink formats: logfmt doesn't dedupe repeated keys the way JSON's
+// map-based encoder incidentally does, so a handler that merely appends a
+// corrected "file"/"function" pair (rather than dropping the stale one)
+// would leak both into logfmt output.
+func TestNewSlogHandlerOverridesCallerKeyvals(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		format LogFormat
+	}{
+		{"json", FormatJson},
+		{"logfmt", FormatLogfmt},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			setSinks([]Sink{newSink(tc.format, &buf, nil)})
+			t.Cleanup(func() { setSinks(nil) })
+
+			base := levels{dynamicSinkLogger{}}.With(
+				"file", "init-file", "function", "init-func",
+			)
+
+			h := NewSlogHandler(base)
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "hi", 0)
+			if err := h.Handle(context.Background(), r); err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+
+			out := buf.String()
+			if strings.Contains(out, "init-file") || strings.Contains(out, "init-func") {
+				t.Fatalf("stale file/function keyvals leaked into output: %q", out)
+			}
+
+			switch tc.format {
+			case FormatLogfmt:
+				if n := strings.Count(out, "file="); n != 1 {
+					t.Fatalf("expected exactly one file= in logfmt output, got %d: %q", n, out)
+				}
+				if n := strings.Count(out, "function="); n != 1 {
+					t.Fatalf("expected exactly one function= in logfmt output, got %d: %q", n, out)
+				}
+			case FormatJson:
+				var m map[string]interface{}
+				if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+					t.Fatalf("Unmarshal: %v", err)
+				}
+				if _, ok := m["file"]; !ok {
+					t.Fatalf("expected a file key in JSON output: %q", out)
+				}
+				if _, ok := m["function"]; !ok {
+					t.Fatalf("expected a function key in JSON output: %q", out)
+				}
+			}
+		})
+	}
+}